@@ -0,0 +1,178 @@
+// Command fnvsum computes FNV-1/FNV-1a digests of files, or of standard
+// input when no files are given, streaming through hash.Hash so large inputs
+// don't need to be buffered in memory.
+//
+// Usage:
+//
+//	fnvsum [-bits N] [-variant 1|1a] [-endian big|little] [file ...]
+//	fnvsum -c checksum-file
+//
+// With -c, fnvsum reads a checksum file of "<hex digest>  <path>" lines, the
+// same format sha256sum and friends use, recomputes the digest of each path
+// and reports any mismatch, exiting non-zero if one is found.
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"hash"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/romain-jacotin/FNV-1a/fnv"
+)
+
+// constructors maps each supported width and variant to the fnv package
+// function that builds it.
+var constructors = map[int]map[string]func() hash.Hash{
+	32:   {"1": func() hash.Hash { return fnv.New32() }, "1a": func() hash.Hash { return fnv.New32a() }},
+	64:   {"1": func() hash.Hash { return fnv.New64() }, "1a": func() hash.Hash { return fnv.New64a() }},
+	128:  {"1": func() hash.Hash { return fnv.New128() }, "1a": func() hash.Hash { return fnv.New128a() }},
+	256:  {"1": func() hash.Hash { return fnv.New256() }, "1a": func() hash.Hash { return fnv.New256a() }},
+	512:  {"1": func() hash.Hash { return fnv.New512() }, "1a": func() hash.Hash { return fnv.New512a() }},
+	1024: {"1": func() hash.Hash { return fnv.New1024() }, "1a": func() hash.Hash { return fnv.New1024a() }},
+}
+
+func newHash(bits int, variant string) (func() hash.Hash, error) {
+	byVariant, ok := constructors[bits]
+	if !ok {
+		return nil, fmt.Errorf("unsupported -bits %d (want one of 32, 64, 128, 256, 512, 1024)", bits)
+	}
+	newFn, ok := byVariant[variant]
+	if !ok {
+		return nil, fmt.Errorf("unsupported -variant %q (want \"1\" or \"1a\")", variant)
+	}
+	return newFn, nil
+}
+
+// formatDigest renders a digest as lowercase hex. fnv.Sum always returns its
+// digest big-endian; for -endian little the byte order is reversed first.
+func formatDigest(sum []byte, endian string) (string, error) {
+	switch endian {
+	case "big":
+		return fmt.Sprintf("%x", sum), nil
+	case "little":
+		reversed := make([]byte, len(sum))
+		for i, b := range sum {
+			reversed[len(sum)-1-i] = b
+		}
+		return fmt.Sprintf("%x", reversed), nil
+	default:
+		return "", fmt.Errorf("unsupported -endian %q (want \"big\" or \"little\")", endian)
+	}
+}
+
+// sumReader streams r through a fresh hash from newFn and returns its digest
+// formatted per endian.
+func sumReader(newFn func() hash.Hash, r io.Reader, endian string) (string, error) {
+	h := newFn()
+	if _, err := io.Copy(h, r); err != nil {
+		return "", err
+	}
+	return formatDigest(h.Sum(nil), endian)
+}
+
+func sumFile(newFn func() hash.Hash, path, endian string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	return sumReader(newFn, f, endian)
+}
+
+// verifyChecksums reads "<hex digest>  <path>" lines from checksumFile,
+// recomputes each path's digest and reports mismatches. Malformed lines and
+// unreadable files are reported and counted as failures without stopping the
+// scan. It returns the number of lines that failed to verify.
+func verifyChecksums(newFn func() hash.Hash, checksumFile, endian string) (int, error) {
+	f, err := os.Open(checksumFile)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+
+	failures := 0
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r\n")
+		if line == "" {
+			continue
+		}
+		want, path, ok := strings.Cut(line, "  ")
+		if !ok {
+			fmt.Fprintf(os.Stderr, "fnvsum: malformed checksum line %q\n", line)
+			failures++
+			continue
+		}
+
+		got, err := sumFile(newFn, path, endian)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %v\n", path, err)
+			failures++
+			continue
+		}
+		if got != want {
+			fmt.Printf("%s: FAILED\n", path)
+			failures++
+			continue
+		}
+		fmt.Printf("%s: OK\n", path)
+	}
+	return failures, scanner.Err()
+}
+
+func main() {
+	bits := flag.Int("bits", 64, "digest width: 32, 64, 128, 256, 512 or 1024")
+	variant := flag.String("variant", "1a", `FNV variant: "1" or "1a"`)
+	endian := flag.String("endian", "big", `digest byte order: "big" or "little"`)
+	checksumFile := flag.String("c", "", "verify digests listed in `checksum-file` instead of printing them")
+	flag.Parse()
+
+	newFn, err := newHash(*bits, *variant)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "fnvsum:", err)
+		os.Exit(2)
+	}
+	if _, err := formatDigest(nil, *endian); err != nil {
+		fmt.Fprintln(os.Stderr, "fnvsum:", err)
+		os.Exit(2)
+	}
+
+	if *checksumFile != "" {
+		failures, err := verifyChecksums(newFn, *checksumFile, *endian)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "fnvsum:", err)
+			os.Exit(2)
+		}
+		if failures > 0 {
+			os.Exit(1)
+		}
+		return
+	}
+
+	paths := flag.Args()
+	if len(paths) == 0 {
+		sum, err := sumReader(newFn, os.Stdin, *endian)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "fnvsum:", err)
+			os.Exit(1)
+		}
+		fmt.Println(sum)
+		return
+	}
+
+	exitCode := 0
+	for _, path := range paths {
+		sum, err := sumFile(newFn, path, *endian)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "fnvsum:", err)
+			exitCode = 1
+			continue
+		}
+		fmt.Printf("%s  %s\n", sum, path)
+	}
+	os.Exit(exitCode)
+}
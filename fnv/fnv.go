@@ -0,0 +1,706 @@
+// Package fnv implements FNV-1 and FNV-1a, non-cryptographic hash functions
+// created by Glenn Fowler, Landon Curt Noll, and Phong Vo. See
+// https://isthe.com/chongo/tech/comp/fnv/ for the reference algorithm.
+//
+// FNV-1 and FNV-1a share the same offset basis and prime for a given width;
+// they differ only in the order of the xor and multiply steps inside the
+// inner loop. FNV-1a (xor then multiply) has better avalanche behaviour and
+// is the variant most users want; FNV-1 (multiply then xor) is provided for
+// compatibility with the reference algorithm and other implementations.
+//
+// All the hash.Hash implementations returned by this package are written to
+// and summed incrementally, exactly like the hash.Hash values returned by the
+// standard library's hash/fnv package: Write folds bytes into the running
+// state, Sum appends the current digest in big-endian order without
+// mutating that state, and Reset restores the offset basis. They also
+// implement encoding.BinaryMarshaler and encoding.BinaryUnmarshaler, so a
+// long-running rolling hash can be checkpointed and resumed without
+// re-reading the data seen so far.
+//
+// The 128, 256, 512 and 1024 bit variants are not offered by the standard
+// library. Because Go has no native integer types of those widths, their
+// running state is kept internally as an array of uint64 "limbs", least
+// significant limb first. Multiplying by the prime uses math/bits.Mul64 and
+// math/bits.Add64 directly on full 64-bit limbs: every FNV prime above 64
+// bits has the form (1<<(64*limbOffset+bitShift))+low, with low and bitShift
+// each fitting in a single limb, so the multiply decomposes into "multiply
+// every limb by low" plus "add a copy of the state shifted left by
+// limbOffset limbs and bitShift bits", each a linear pass with carry
+// propagation instead of a full N*N-limb multiplication. See mulAddPrime.
+package fnv
+
+import (
+	"errors"
+	"hash"
+	"math/bits"
+)
+
+// Magic prefixes identifying a marshaled hash's algorithm and width, modeled
+// after the standard library's hash/fnv: "fnv" followed by a byte giving the
+// width index (1=32, 2=64, 3=128, 4=256, 5=512, 6=1024 bits), with a
+// trailing 'a' for the FNV-1a variants.
+const (
+	magic32  = "fnv\x01"
+	magic32a = "fnv\x01a"
+
+	magic64  = "fnv\x02"
+	magic64a = "fnv\x02a"
+
+	magic128  = "fnv\x03"
+	magic128a = "fnv\x03a"
+
+	magic256  = "fnv\x04"
+	magic256a = "fnv\x04a"
+
+	magic512  = "fnv\x05"
+	magic512a = "fnv\x05a"
+
+	magic1024  = "fnv\x06"
+	magic1024a = "fnv\x06a"
+)
+
+var errMismatchedHashState = errors.New("fnv: invalid hash state identifier")
+
+// New32 returns a new 32-bit FNV-1 hash.Hash32.
+func New32() hash.Hash32 {
+	var s sum32 = offset32
+	return &s
+}
+
+// New32a returns a new 32-bit FNV-1a hash.Hash32.
+func New32a() hash.Hash32 {
+	var s sum32a = offset32
+	return &s
+}
+
+// New64 returns a new 64-bit FNV-1 hash.Hash64.
+func New64() hash.Hash64 {
+	var s sum64 = offset64
+	return &s
+}
+
+// New64a returns a new 64-bit FNV-1a hash.Hash64.
+func New64a() hash.Hash64 {
+	var s sum64a = offset64
+	return &s
+}
+
+// New128 returns a new 128-bit FNV-1 hash.Hash.
+func New128() hash.Hash {
+	s := new(sum128)
+	s.Reset()
+	return s
+}
+
+// New128a returns a new 128-bit FNV-1a hash.Hash.
+func New128a() hash.Hash {
+	s := new(sum128a)
+	s.Reset()
+	return s
+}
+
+// New256 returns a new 256-bit FNV-1 hash.Hash.
+func New256() hash.Hash {
+	s := new(sum256)
+	s.Reset()
+	return s
+}
+
+// New256a returns a new 256-bit FNV-1a hash.Hash.
+func New256a() hash.Hash {
+	s := new(sum256a)
+	s.Reset()
+	return s
+}
+
+// New512 returns a new 512-bit FNV-1 hash.Hash.
+func New512() hash.Hash {
+	s := new(sum512)
+	s.Reset()
+	return s
+}
+
+// New512a returns a new 512-bit FNV-1a hash.Hash.
+func New512a() hash.Hash {
+	s := new(sum512a)
+	s.Reset()
+	return s
+}
+
+// New1024 returns a new 1024-bit FNV-1 hash.Hash.
+func New1024() hash.Hash {
+	s := new(sum1024)
+	s.Reset()
+	return s
+}
+
+// New1024a returns a new 1024-bit FNV-1a hash.Hash.
+func New1024a() hash.Hash {
+	s := new(sum1024a)
+	s.Reset()
+	return s
+}
+
+const (
+	offset32 = 2166136261
+	prime32  = 16777619
+
+	offset64 = 14695981039346656037
+	prime64  = 1099511628211
+)
+
+type sum32a uint32
+
+func (s *sum32a) Write(data []byte) (int, error) {
+	hash := *s
+	for _, c := range data {
+		hash ^= sum32a(c)
+		hash *= prime32
+	}
+	*s = hash
+	return len(data), nil
+}
+
+func (s *sum32a) Sum(in []byte) []byte {
+	v := uint32(*s)
+	return append(in, byte(v>>24), byte(v>>16), byte(v>>8), byte(v))
+}
+
+func (s *sum32a) Reset() { *s = offset32 }
+
+func (s *sum32a) Sum32() uint32 { return uint32(*s) }
+
+func (*sum32a) Size() int { return 4 }
+
+func (*sum32a) BlockSize() int { return 1 }
+
+func (s *sum32a) MarshalBinary() ([]byte, error) {
+	return append([]byte(magic32a), s.Sum(nil)...), nil
+}
+
+func (s *sum32a) UnmarshalBinary(b []byte) error {
+	if len(b) != len(magic32a)+4 || string(b[:len(magic32a)]) != magic32a {
+		return errMismatchedHashState
+	}
+	b = b[len(magic32a):]
+	*s = sum32a(uint32(b[0])<<24 | uint32(b[1])<<16 | uint32(b[2])<<8 | uint32(b[3]))
+	return nil
+}
+
+type sum32 uint32
+
+func (s *sum32) Write(data []byte) (int, error) {
+	hash := *s
+	for _, c := range data {
+		hash *= prime32
+		hash ^= sum32(c)
+	}
+	*s = hash
+	return len(data), nil
+}
+
+func (s *sum32) Sum(in []byte) []byte {
+	v := uint32(*s)
+	return append(in, byte(v>>24), byte(v>>16), byte(v>>8), byte(v))
+}
+
+func (s *sum32) Reset() { *s = offset32 }
+
+func (s *sum32) Sum32() uint32 { return uint32(*s) }
+
+func (*sum32) Size() int { return 4 }
+
+func (*sum32) BlockSize() int { return 1 }
+
+func (s *sum32) MarshalBinary() ([]byte, error) {
+	return append([]byte(magic32), s.Sum(nil)...), nil
+}
+
+func (s *sum32) UnmarshalBinary(b []byte) error {
+	if len(b) != len(magic32)+4 || string(b[:len(magic32)]) != magic32 {
+		return errMismatchedHashState
+	}
+	b = b[len(magic32):]
+	*s = sum32(uint32(b[0])<<24 | uint32(b[1])<<16 | uint32(b[2])<<8 | uint32(b[3]))
+	return nil
+}
+
+type sum64a uint64
+
+func (s *sum64a) Write(data []byte) (int, error) {
+	hash := *s
+	for _, c := range data {
+		hash ^= sum64a(c)
+		hash *= prime64
+	}
+	*s = hash
+	return len(data), nil
+}
+
+func (s *sum64a) Sum(in []byte) []byte {
+	v := uint64(*s)
+	return append(in, byte(v>>56), byte(v>>48), byte(v>>40), byte(v>>32),
+		byte(v>>24), byte(v>>16), byte(v>>8), byte(v))
+}
+
+func (s *sum64a) Reset() { *s = offset64 }
+
+func (s *sum64a) Sum64() uint64 { return uint64(*s) }
+
+func (*sum64a) Size() int { return 8 }
+
+func (*sum64a) BlockSize() int { return 1 }
+
+func (s *sum64a) MarshalBinary() ([]byte, error) {
+	return append([]byte(magic64a), s.Sum(nil)...), nil
+}
+
+func (s *sum64a) UnmarshalBinary(b []byte) error {
+	if len(b) != len(magic64a)+8 || string(b[:len(magic64a)]) != magic64a {
+		return errMismatchedHashState
+	}
+	b = b[len(magic64a):]
+	var v uint64
+	for _, c := range b {
+		v = v<<8 | uint64(c)
+	}
+	*s = sum64a(v)
+	return nil
+}
+
+type sum64 uint64
+
+func (s *sum64) Write(data []byte) (int, error) {
+	hash := *s
+	for _, c := range data {
+		hash *= prime64
+		hash ^= sum64(c)
+	}
+	*s = hash
+	return len(data), nil
+}
+
+func (s *sum64) Sum(in []byte) []byte {
+	v := uint64(*s)
+	return append(in, byte(v>>56), byte(v>>48), byte(v>>40), byte(v>>32),
+		byte(v>>24), byte(v>>16), byte(v>>8), byte(v))
+}
+
+func (s *sum64) Reset() { *s = offset64 }
+
+func (s *sum64) Sum64() uint64 { return uint64(*s) }
+
+func (*sum64) Size() int { return 8 }
+
+func (*sum64) BlockSize() int { return 1 }
+
+func (s *sum64) MarshalBinary() ([]byte, error) {
+	return append([]byte(magic64), s.Sum(nil)...), nil
+}
+
+func (s *sum64) UnmarshalBinary(b []byte) error {
+	if len(b) != len(magic64)+8 || string(b[:len(magic64)]) != magic64 {
+		return errMismatchedHashState
+	}
+	b = b[len(magic64):]
+	var v uint64
+	for _, c := range b {
+		v = v<<8 | uint64(c)
+	}
+	*s = sum64(v)
+	return nil
+}
+
+// mulAddPrime multiplies the little-endian limb array val by an FNV prime of
+// the form (1<<(64*limbOffset+bitShift))+low, writing the result into tmp.
+// This decomposes the multiply into two linear passes instead of a full
+// N*N-limb multiplication: first tmp[i] = val[i]*low with carry propagation,
+// then tmp[i] gains a copy of val shifted left by limbOffset limbs and
+// bitShift bits, again with carry propagation. val and tmp must have the
+// same length and must not overlap.
+func mulAddPrime(val, tmp []uint64, low uint64, limbOffset int, bitShift uint) {
+	var carry uint64
+	for i := range val {
+		hi, lo := bits.Mul64(val[i], low)
+		lo, cy := bits.Add64(lo, carry, 0)
+		tmp[i] = lo
+		carry = hi + cy
+	}
+
+	var addCarry uint64
+	for i := limbOffset; i < len(val); i++ {
+		shifted := val[i-limbOffset] << bitShift
+		if i-limbOffset > 0 {
+			shifted |= val[i-limbOffset-1] >> (64 - bitShift)
+		}
+		tmp[i], addCarry = bits.Add64(tmp[i], shifted, addCarry)
+	}
+}
+
+// sum128a holds the running 128-bit state as two uint64 limbs, least
+// significant limb first.
+type sum128a [2]uint64
+
+// The 128-bit fnv_prime = 0x0000000001000000000000000000013B has the form
+// (1<<(64*limbOffset+bitShift))+low.
+const (
+	prime128Low        = 0x13B
+	prime128LimbOffset = 1
+	prime128BitShift   = 24
+)
+
+func (s *sum128a) Write(data []byte) (int, error) {
+	val := *s
+	var tmp [2]uint64
+	for _, c := range data {
+		val[0] ^= uint64(c)
+		mulAddPrime(val[:], tmp[:], prime128Low, prime128LimbOffset, prime128BitShift)
+		val = tmp
+	}
+	*s = val
+	return len(data), nil
+}
+
+func (s *sum128a) Sum(in []byte) []byte {
+	for i := len(s) - 1; i >= 0; i-- {
+		limb := s[i]
+		in = append(in, byte(limb>>56), byte(limb>>48), byte(limb>>40), byte(limb>>32),
+			byte(limb>>24), byte(limb>>16), byte(limb>>8), byte(limb))
+	}
+	return in
+}
+
+func (s *sum128a) Reset() {
+	// offset_basis = 0x6C62272E07BB0142 62B821756295C58D
+	*s = sum128a{0x62B821756295C58D, 0x6C62272E07BB0142}
+}
+
+func (*sum128a) Size() int { return 16 }
+
+func (*sum128a) BlockSize() int { return 1 }
+
+// unmarshalState decodes the big-endian digest bytes produced by Sum back
+// into limbs, the inverse of Sum.
+func (s *sum128a) unmarshalState(b []byte) {
+	for i := range s {
+		o := (len(s) - 1 - i) * 8
+		s[i] = uint64(b[o])<<56 | uint64(b[o+1])<<48 | uint64(b[o+2])<<40 | uint64(b[o+3])<<32 |
+			uint64(b[o+4])<<24 | uint64(b[o+5])<<16 | uint64(b[o+6])<<8 | uint64(b[o+7])
+	}
+}
+
+func (s *sum128a) MarshalBinary() ([]byte, error) {
+	return append([]byte(magic128a), s.Sum(nil)...), nil
+}
+
+func (s *sum128a) UnmarshalBinary(b []byte) error {
+	if len(b) != len(magic128a)+16 || string(b[:len(magic128a)]) != magic128a {
+		return errMismatchedHashState
+	}
+	s.unmarshalState(b[len(magic128a):])
+	return nil
+}
+
+// sum128 is the FNV-1 counterpart of sum128a: it multiplies by the prime
+// before xoring in the octet instead of after. Reset, Sum, Size and
+// BlockSize are identical between the two variants, so sum128 embeds
+// sum128a to reuse them and only overrides Write.
+type sum128 struct{ sum128a }
+
+func (s *sum128) Write(data []byte) (int, error) {
+	val := s.sum128a
+	var tmp [2]uint64
+	for _, c := range data {
+		mulAddPrime(val[:], tmp[:], prime128Low, prime128LimbOffset, prime128BitShift)
+		tmp[0] ^= uint64(c)
+		val = tmp
+	}
+	s.sum128a = val
+	return len(data), nil
+}
+
+func (s *sum128) MarshalBinary() ([]byte, error) {
+	return append([]byte(magic128), s.Sum(nil)...), nil
+}
+
+func (s *sum128) UnmarshalBinary(b []byte) error {
+	if len(b) != len(magic128)+16 || string(b[:len(magic128)]) != magic128 {
+		return errMismatchedHashState
+	}
+	s.sum128a.unmarshalState(b[len(magic128):])
+	return nil
+}
+
+// sum256a holds the running 256-bit state as four uint64 limbs, least
+// significant limb first.
+type sum256a [4]uint64
+
+// The 256-bit fnv_prime has the form (1<<(64*limbOffset+bitShift))+low.
+const (
+	prime256Low        = 0x163
+	prime256LimbOffset = 2
+	prime256BitShift   = 40
+)
+
+func (s *sum256a) Write(data []byte) (int, error) {
+	val := *s
+	var tmp [4]uint64
+	for _, c := range data {
+		val[0] ^= uint64(c)
+		mulAddPrime(val[:], tmp[:], prime256Low, prime256LimbOffset, prime256BitShift)
+		val = tmp
+	}
+	*s = val
+	return len(data), nil
+}
+
+func (s *sum256a) Sum(in []byte) []byte {
+	for i := len(s) - 1; i >= 0; i-- {
+		limb := s[i]
+		in = append(in, byte(limb>>56), byte(limb>>48), byte(limb>>40), byte(limb>>32),
+			byte(limb>>24), byte(limb>>16), byte(limb>>8), byte(limb))
+	}
+	return in
+}
+
+func (s *sum256a) Reset() {
+	// offset_basis = 0xDD268DBCAAC55036 2D98C384C4E576CC 47B6BBB3C8B15368 1023B4C8CAEE0535
+	*s = sum256a{0x1023B4C8CAEE0535, 0xC8B1536847B6BBB3, 0x2D98C384C4E576CC, 0xDD268DBCAAC55036}
+}
+
+func (*sum256a) Size() int { return 32 }
+
+func (*sum256a) BlockSize() int { return 1 }
+
+func (s *sum256a) unmarshalState(b []byte) {
+	for i := range s {
+		o := (len(s) - 1 - i) * 8
+		s[i] = uint64(b[o])<<56 | uint64(b[o+1])<<48 | uint64(b[o+2])<<40 | uint64(b[o+3])<<32 |
+			uint64(b[o+4])<<24 | uint64(b[o+5])<<16 | uint64(b[o+6])<<8 | uint64(b[o+7])
+	}
+}
+
+func (s *sum256a) MarshalBinary() ([]byte, error) {
+	return append([]byte(magic256a), s.Sum(nil)...), nil
+}
+
+func (s *sum256a) UnmarshalBinary(b []byte) error {
+	if len(b) != len(magic256a)+32 || string(b[:len(magic256a)]) != magic256a {
+		return errMismatchedHashState
+	}
+	s.unmarshalState(b[len(magic256a):])
+	return nil
+}
+
+// sum256 is the FNV-1 counterpart of sum256a; see sum128 for why it embeds
+// sum256a and only overrides Write.
+type sum256 struct{ sum256a }
+
+func (s *sum256) Write(data []byte) (int, error) {
+	val := s.sum256a
+	var tmp [4]uint64
+	for _, c := range data {
+		mulAddPrime(val[:], tmp[:], prime256Low, prime256LimbOffset, prime256BitShift)
+		tmp[0] ^= uint64(c)
+		val = tmp
+	}
+	s.sum256a = val
+	return len(data), nil
+}
+
+func (s *sum256) MarshalBinary() ([]byte, error) {
+	return append([]byte(magic256), s.Sum(nil)...), nil
+}
+
+func (s *sum256) UnmarshalBinary(b []byte) error {
+	if len(b) != len(magic256)+32 || string(b[:len(magic256)]) != magic256 {
+		return errMismatchedHashState
+	}
+	s.sum256a.unmarshalState(b[len(magic256):])
+	return nil
+}
+
+// sum512a holds the running 512-bit state as eight uint64 limbs, least
+// significant limb first.
+type sum512a [8]uint64
+
+// The 512-bit fnv_prime has the form (1<<(64*limbOffset+bitShift))+low.
+const (
+	prime512Low        = 0x157
+	prime512LimbOffset = 5
+	prime512BitShift   = 24
+)
+
+func (s *sum512a) Write(data []byte) (int, error) {
+	val := *s
+	var tmp [8]uint64
+	for _, c := range data {
+		val[0] ^= uint64(c)
+		mulAddPrime(val[:], tmp[:], prime512Low, prime512LimbOffset, prime512BitShift)
+		val = tmp
+	}
+	*s = val
+	return len(data), nil
+}
+
+func (s *sum512a) Sum(in []byte) []byte {
+	for i := len(s) - 1; i >= 0; i-- {
+		limb := s[i]
+		in = append(in, byte(limb>>56), byte(limb>>48), byte(limb>>40), byte(limb>>32),
+			byte(limb>>24), byte(limb>>16), byte(limb>>8), byte(limb))
+	}
+	return in
+}
+
+func (s *sum512a) Reset() {
+	// offset_basis, high to low limb:
+	// 0xB86DB0B1171F4416 0xDCA1E50F309990AC 0xAC87D059C9000000 0x0000000000000D21
+	// 0xE948F68A34C192F6 0x2EA79BC942DBE7CE 0x182036415F56E34B 0xAC982AAC4AFE9FD9
+	*s = sum512a{0xAC982AAC4AFE9FD9, 0x182036415F56E34B, 0x2EA79BC942DBE7CE, 0xE948F68A34C192F6,
+		0x0000000000000D21, 0xAC87D059C9000000, 0xDCA1E50F309990AC, 0xB86DB0B1171F4416}
+}
+
+func (*sum512a) Size() int { return 64 }
+
+func (*sum512a) BlockSize() int { return 1 }
+
+func (s *sum512a) unmarshalState(b []byte) {
+	for i := range s {
+		o := (len(s) - 1 - i) * 8
+		s[i] = uint64(b[o])<<56 | uint64(b[o+1])<<48 | uint64(b[o+2])<<40 | uint64(b[o+3])<<32 |
+			uint64(b[o+4])<<24 | uint64(b[o+5])<<16 | uint64(b[o+6])<<8 | uint64(b[o+7])
+	}
+}
+
+func (s *sum512a) MarshalBinary() ([]byte, error) {
+	return append([]byte(magic512a), s.Sum(nil)...), nil
+}
+
+func (s *sum512a) UnmarshalBinary(b []byte) error {
+	if len(b) != len(magic512a)+64 || string(b[:len(magic512a)]) != magic512a {
+		return errMismatchedHashState
+	}
+	s.unmarshalState(b[len(magic512a):])
+	return nil
+}
+
+// sum512 is the FNV-1 counterpart of sum512a; see sum128 for why it embeds
+// sum512a and only overrides Write.
+type sum512 struct{ sum512a }
+
+func (s *sum512) Write(data []byte) (int, error) {
+	val := s.sum512a
+	var tmp [8]uint64
+	for _, c := range data {
+		mulAddPrime(val[:], tmp[:], prime512Low, prime512LimbOffset, prime512BitShift)
+		tmp[0] ^= uint64(c)
+		val = tmp
+	}
+	s.sum512a = val
+	return len(data), nil
+}
+
+func (s *sum512) MarshalBinary() ([]byte, error) {
+	return append([]byte(magic512), s.Sum(nil)...), nil
+}
+
+func (s *sum512) UnmarshalBinary(b []byte) error {
+	if len(b) != len(magic512)+64 || string(b[:len(magic512)]) != magic512 {
+		return errMismatchedHashState
+	}
+	s.sum512a.unmarshalState(b[len(magic512):])
+	return nil
+}
+
+// sum1024a holds the running 1024-bit state as sixteen uint64 limbs, least
+// significant limb first.
+type sum1024a [16]uint64
+
+// The 1024-bit fnv_prime has the form (1<<(64*limbOffset+bitShift))+low.
+const (
+	prime1024Low        = 0x18D
+	prime1024LimbOffset = 10
+	prime1024BitShift   = 40
+)
+
+func (s *sum1024a) Write(data []byte) (int, error) {
+	val := *s
+	var tmp [16]uint64
+	for _, c := range data {
+		val[0] ^= uint64(c)
+		mulAddPrime(val[:], tmp[:], prime1024Low, prime1024LimbOffset, prime1024BitShift)
+		val = tmp
+	}
+	*s = val
+	return len(data), nil
+}
+
+func (s *sum1024a) Sum(in []byte) []byte {
+	for i := len(s) - 1; i >= 0; i-- {
+		limb := s[i]
+		in = append(in, byte(limb>>56), byte(limb>>48), byte(limb>>40), byte(limb>>32),
+			byte(limb>>24), byte(limb>>16), byte(limb>>8), byte(limb))
+	}
+	return in
+}
+
+func (s *sum1024a) Reset() {
+	// offset_basis, high to low limb:
+	// 0x000000000000000000005F7A76758ECC4D limbs continue below (16 limbs total)
+	*s = sum1024a{0xAFF4B16C71EE90B3, 0x6BDE8CC9C6A93B21, 0x555F256CC005AE55, 0xEB6E73802734510A,
+		0x000000000004C6D7, 0x0000000000000000, 0x0000000000000000, 0x0000000000000000,
+		0x0000000000000000, 0x0000000000000000, 0x9A21D90000000000, 0x6C3BF34EDA3674DA,
+		0x4B29FC4223FDADA1, 0x32E56D5A591028B7, 0x005F7A76758ECC4D, 0x0000000000000000}
+}
+
+func (*sum1024a) Size() int { return 128 }
+
+func (*sum1024a) BlockSize() int { return 1 }
+
+func (s *sum1024a) unmarshalState(b []byte) {
+	for i := range s {
+		o := (len(s) - 1 - i) * 8
+		s[i] = uint64(b[o])<<56 | uint64(b[o+1])<<48 | uint64(b[o+2])<<40 | uint64(b[o+3])<<32 |
+			uint64(b[o+4])<<24 | uint64(b[o+5])<<16 | uint64(b[o+6])<<8 | uint64(b[o+7])
+	}
+}
+
+func (s *sum1024a) MarshalBinary() ([]byte, error) {
+	return append([]byte(magic1024a), s.Sum(nil)...), nil
+}
+
+func (s *sum1024a) UnmarshalBinary(b []byte) error {
+	if len(b) != len(magic1024a)+128 || string(b[:len(magic1024a)]) != magic1024a {
+		return errMismatchedHashState
+	}
+	s.unmarshalState(b[len(magic1024a):])
+	return nil
+}
+
+// sum1024 is the FNV-1 counterpart of sum1024a; see sum128 for why it
+// embeds sum1024a and only overrides Write.
+type sum1024 struct{ sum1024a }
+
+func (s *sum1024) Write(data []byte) (int, error) {
+	val := s.sum1024a
+	var tmp [16]uint64
+	for _, c := range data {
+		mulAddPrime(val[:], tmp[:], prime1024Low, prime1024LimbOffset, prime1024BitShift)
+		tmp[0] ^= uint64(c)
+		val = tmp
+	}
+	s.sum1024a = val
+	return len(data), nil
+}
+
+func (s *sum1024) MarshalBinary() ([]byte, error) {
+	return append([]byte(magic1024), s.Sum(nil)...), nil
+}
+
+func (s *sum1024) UnmarshalBinary(b []byte) error {
+	if len(b) != len(magic1024)+128 || string(b[:len(magic1024)]) != magic1024 {
+		return errMismatchedHashState
+	}
+	s.sum1024a.unmarshalState(b[len(magic1024):])
+	return nil
+}
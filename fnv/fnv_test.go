@@ -0,0 +1,368 @@
+package fnv
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/hex"
+	"hash"
+	"math/rand"
+	"testing"
+)
+
+type goldenCase struct {
+	in       string
+	out32    string
+	out32a   string
+	out64    string
+	out64a   string
+	out128   string
+	out128a  string
+	out256   string
+	out256a  string
+	out512   string
+	out512a  string
+	out1024  string
+	out1024a string
+}
+
+var golden = []goldenCase{
+	{
+		in:      "hello world!goodbye!",
+		out32:   "6aaa1636",
+		out32a:  "113baa28",
+		out64:   "515e410ecb6c0136",
+		out64a:  "50015c195ee3d588",
+		out128:  "204f0d1a59891f44e875d0072dddc3c6",
+		out128a: "aebb096b13b291473b18f8448a446fa0",
+		out256:  "adf5c9a9d4ca45a1420e62067b38da72611b5b51b5d15482d817a9181438e1f6",
+		out256a: "a3846d0515e77985b8e15916d15c1ffd2ead3cf20a78e15a4ab0c023728fc0f8",
+		out512: "f2a7a67ce90a0a27a5d409e51d11f711c3a6ea976d1988b4d6e4efc77313664f" +
+			"3729c4451f1a07bd42455ef5935e290aa3d7e8345d69d31de89619176533f82e",
+		out512a: "c80e61d5ce3ae0d34e0b0f185f8a7415ef835a36b01988b4d6e4efc77313664f" +
+			"3729c4451f1a07bd424528a7e61e7c1d4ae33c3c8cc14732f25f367983920b84",
+		out1024: "b897b5105c6e6e597783b1cee11c5a1efe09d71e8244826253bfece76812aeb1" +
+			"ef097b393b8ad88637c2c6000000000000000000000000000000000000000000" +
+			"000000000000000078c10a52e7afdaf167e608756cd3d3145361343de4d43872" +
+			"c6d1c526c07e9b4250b4d87f7680b00f825fbd91deda2eecf60476328643be32",
+		out1024a: "b897b5105c6e6e597783b1cee11c5a1efe09d71e8277697a9f0cdef458b193f2" +
+			"4fbd9a715d5667f5d7c891000000000000000000000000000000000000000000" +
+			"000000000000000078c10a52e7afdaf167e608756cd3d3145361343de4d43872" +
+			"c6d1c526c07e9b4250b8cae0a961eebbcb5073c67bdb76f6f4d2b60ba30564ce",
+	},
+	{
+		in:      "I am a gopher!",
+		out32:   "dad6896d",
+		out32a:  "7b0b53e9",
+		out64:   "d3c607800915f32d",
+		out64a:  "9fb4685c5284a9a9",
+		out128:  "86c3d353590726c892e87cce1eccf09d",
+		out128a: "2b178bc6a6071ec752fa46a01e21fb29",
+		out256:  "740b28a09338812d165895c9fd2f06c82c028aa0a9015c62773c7223417bd68d",
+		out256a: "fc677e8e771c54ff23ae33c9fd2f06c84705a4bedbb9b6c7cdb2950cedaec1e9",
+		out512: "da97ee2f631ff178e27242476e55b380936319afa415371cfa15ca2f33fc042c" +
+			"8f61f8cfa1b2b3b79939f7347fce32312faf8afb02786e2e2bafaac0164aec35",
+		out512a: "da97ee2f631f3dde7d55d98e724023423b4baa8aea15371cfa15ca2f33fc042c" +
+			"8f61f8cfa1b2b3b79939f7347fce32311e7e2da79a5dca99ea495284cd738669",
+		out1024: "85f2d26936ded8f66cc5835aed0b37dc11e51448a21279a31fded09bc90494cf5" +
+			"99794cbddc2529de3384c000000000000000000000000000000000000000000" +
+			"000000000000000000000000000008ae7badf407f5732a48d3f14e1ba8722279" +
+			"5202b88ad18d7790957105101fe5b99d7eaf989ce1db322fd61dec3762bb22ed",
+		out1024a: "85f2d26936ded8f66cc5835aed0b37dc11e51448a21279a31fded0a051fe5508" +
+			"e8a3928f03df0faedddf72000000000000000000000000000000000000000000" +
+			"000000000000000000000000000008ae7badf407f5732a48d3f14e1ba8722279" +
+			"5202b88ad18d7790957105101fe5b99dff40def61e106358cea348f2d6a64da5",
+	},
+}
+
+// testGolden checks newHash against the wanted hex digest returned by want
+// for every golden case, including round-tripping through Reset and that
+// Sum does not mutate the running state.
+func testGolden(t *testing.T, newHash func() hash.Hash, want func(g goldenCase) string) {
+	t.Helper()
+	for _, g := range golden {
+		wantBytes, err := hex.DecodeString(want(g))
+		if err != nil {
+			t.Fatalf("invalid hex fixture for %q: %v", g.in, err)
+		}
+
+		h := newHash()
+		if _, err := h.Write([]byte(g.in)); err != nil {
+			t.Fatalf("Write(%q) failed: %v", g.in, err)
+		}
+		if got := h.Sum(nil); !bytes.Equal(got, wantBytes) {
+			t.Errorf("Sum(%q) = %x, want %x", g.in, got, wantBytes)
+		}
+		if got := h.Sum(nil); !bytes.Equal(got, wantBytes) {
+			t.Errorf("second Sum(%q) = %x, want %x, Sum must not mutate state", g.in, got, wantBytes)
+		}
+
+		h.Reset()
+		if _, err := h.Write([]byte(g.in)); err != nil {
+			t.Fatalf("Write(%q) after Reset failed: %v", g.in, err)
+		}
+		if got := h.Sum(nil); !bytes.Equal(got, wantBytes) {
+			t.Errorf("Sum(%q) after Reset = %x, want %x", g.in, got, wantBytes)
+		}
+	}
+}
+
+func TestNew32(t *testing.T) {
+	testGolden(t, func() hash.Hash { return New32() }, func(g goldenCase) string { return g.out32 })
+}
+
+func TestNew32a(t *testing.T) {
+	testGolden(t, func() hash.Hash { return New32a() }, func(g goldenCase) string { return g.out32a })
+}
+
+func TestNew64(t *testing.T) {
+	testGolden(t, func() hash.Hash { return New64() }, func(g goldenCase) string { return g.out64 })
+}
+
+func TestNew64a(t *testing.T) {
+	testGolden(t, func() hash.Hash { return New64a() }, func(g goldenCase) string { return g.out64a })
+}
+
+func TestNew128(t *testing.T) {
+	testGolden(t, New128, func(g goldenCase) string { return g.out128 })
+}
+
+func TestNew128a(t *testing.T) {
+	testGolden(t, New128a, func(g goldenCase) string { return g.out128a })
+}
+
+func TestNew256(t *testing.T) {
+	testGolden(t, New256, func(g goldenCase) string { return g.out256 })
+}
+
+func TestNew256a(t *testing.T) {
+	testGolden(t, New256a, func(g goldenCase) string { return g.out256a })
+}
+
+func TestNew512(t *testing.T) {
+	testGolden(t, New512, func(g goldenCase) string { return g.out512 })
+}
+
+func TestNew512a(t *testing.T) {
+	testGolden(t, New512a, func(g goldenCase) string { return g.out512a })
+}
+
+func TestNew1024(t *testing.T) {
+	testGolden(t, New1024, func(g goldenCase) string { return g.out1024 })
+}
+
+func TestNew1024a(t *testing.T) {
+	testGolden(t, New1024a, func(g goldenCase) string { return g.out1024a })
+}
+
+func TestSizeAndBlockSize(t *testing.T) {
+	cases := []struct {
+		name string
+		h    hash.Hash
+		size int
+	}{
+		{"New32", New32(), 4},
+		{"New32a", New32a(), 4},
+		{"New64", New64(), 8},
+		{"New64a", New64a(), 8},
+		{"New128", New128(), 16},
+		{"New128a", New128a(), 16},
+		{"New256", New256(), 32},
+		{"New256a", New256a(), 32},
+		{"New512", New512(), 64},
+		{"New512a", New512a(), 64},
+		{"New1024", New1024(), 128},
+		{"New1024a", New1024a(), 128},
+	}
+	for _, c := range cases {
+		if got := c.h.Size(); got != c.size {
+			t.Errorf("%s Size() = %d, want %d", c.name, got, c.size)
+		}
+		if got := c.h.BlockSize(); got != 1 {
+			t.Errorf("%s BlockSize() = %d, want 1", c.name, got)
+		}
+	}
+}
+
+func TestWriteIsIncremental(t *testing.T) {
+	data := []byte("hello world!goodbye!")
+	newHashes := []func() hash.Hash{
+		func() hash.Hash { return New32() },
+		func() hash.Hash { return New32a() },
+		func() hash.Hash { return New64() },
+		func() hash.Hash { return New64a() },
+		New128, New128a, New256, New256a, New512, New512a, New1024, New1024a,
+	}
+	for i, newHash := range newHashes {
+		whole := newHash()
+		whole.Write(data)
+
+		piecewise := newHash()
+		piecewise.Write(data[:5])
+		piecewise.Write(data[5:])
+
+		if got, want := piecewise.Sum(nil), whole.Sum(nil); !bytes.Equal(got, want) {
+			t.Errorf("case %d: incremental Write mismatch: %x != %x", i, got, want)
+		}
+	}
+}
+
+func TestMarshalUnmarshalResumesState(t *testing.T) {
+	data := []byte("hello world!goodbye!")
+	newHashes := []func() hash.Hash{
+		func() hash.Hash { return New32() },
+		func() hash.Hash { return New32a() },
+		func() hash.Hash { return New64() },
+		func() hash.Hash { return New64a() },
+		New128, New128a, New256, New256a, New512, New512a, New1024, New1024a,
+	}
+	for i, newHash := range newHashes {
+		h := newHash()
+		h.Write(data[:5])
+
+		state, err := h.(encoding.BinaryMarshaler).MarshalBinary()
+		if err != nil {
+			t.Fatalf("case %d: MarshalBinary failed: %v", i, err)
+		}
+
+		resumed := newHash()
+		if err := resumed.(encoding.BinaryUnmarshaler).UnmarshalBinary(state); err != nil {
+			t.Fatalf("case %d: UnmarshalBinary failed: %v", i, err)
+		}
+		resumed.Write(data[5:])
+
+		h.Write(data[5:])
+		if got, want := resumed.Sum(nil), h.Sum(nil); !bytes.Equal(got, want) {
+			t.Errorf("case %d: resumed hash mismatch: %x != %x", i, got, want)
+		}
+	}
+}
+
+func TestUnmarshalBinaryRejectsMismatch(t *testing.T) {
+	state, err := New128a().(encoding.BinaryMarshaler).MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary failed: %v", err)
+	}
+
+	if err := New256a().(encoding.BinaryUnmarshaler).UnmarshalBinary(state); err == nil {
+		t.Error("UnmarshalBinary accepted state from a different width")
+	}
+	if err := New128().(encoding.BinaryUnmarshaler).UnmarshalBinary(state); err == nil {
+		t.Error("UnmarshalBinary accepted state from a different variant")
+	}
+	if err := New128a().(encoding.BinaryUnmarshaler).UnmarshalBinary(state[:len(state)-1]); err == nil {
+		t.Error("UnmarshalBinary accepted truncated state")
+	}
+}
+
+// wideDigits computes an FNV-1/1a digest by folding the input through an
+// array of base-2^32 digits, least significant digit first: the
+// representation the 128/256/512/1024-bit Write methods used before they
+// were redesigned around 64-bit limbs and math/bits. digitOffset and shift
+// describe the prime's (1<<(32*digitOffset+shift))+low form in digit units.
+// It exists purely so TestWideRandomCrossCheck can confirm the redesigned
+// Write methods are bit-exact with the original digit-based algorithm.
+func wideDigits(offsetDigits []uint32, primeLow uint32, digitOffset, shift int, variant bool, data []byte) []uint32 {
+	n := len(offsetDigits)
+	val := append([]uint32(nil), offsetDigits...)
+	tmp := make([]uint64, n)
+	for _, c := range data {
+		if variant {
+			val[0] ^= uint32(c)
+		}
+		for i := 0; i < digitOffset; i++ {
+			tmp[i] = uint64(val[i]) * uint64(primeLow)
+		}
+		for i := digitOffset; i < n; i++ {
+			tmp[i] = uint64(val[i])*uint64(primeLow) + uint64(val[i-digitOffset])<<shift
+		}
+		for i := 1; i < n; i++ {
+			tmp[i] += tmp[i-1] >> 32
+		}
+		for i := 0; i < n; i++ {
+			val[i] = uint32(tmp[i])
+		}
+		if !variant {
+			val[0] ^= uint32(c)
+		}
+	}
+	return val
+}
+
+// wideDigitsBigEndian renders the little-endian digit array produced by
+// wideDigits into the big-endian byte digest returned by Sum.
+func wideDigitsBigEndian(val []uint32) []byte {
+	out := make([]byte, 0, len(val)*4)
+	for i := len(val) - 1; i >= 0; i-- {
+		d := val[i]
+		out = append(out, byte(d>>24), byte(d>>16), byte(d>>8), byte(d))
+	}
+	return out
+}
+
+func TestWideRandomCrossCheck(t *testing.T) {
+	cases := []struct {
+		name               string
+		offset             []uint32
+		primeLow           uint32
+		digitOffset, shift int
+		new1, new1a        func() hash.Hash
+	}{
+		{
+			name:     "128",
+			offset:   []uint32{0x6295C58D, 0x62B82175, 0x07BB0142, 0x6C62272E},
+			primeLow: 0x13B, digitOffset: 2, shift: 24,
+			new1: New128, new1a: New128a,
+		},
+		{
+			name:     "256",
+			offset:   []uint32{0xCAEE0535, 0x1023B4C8, 0x47B6BBB3, 0xC8B15368, 0xC4E576CC, 0x2D98C384, 0xAAC55036, 0xDD268DBC},
+			primeLow: 0x163, digitOffset: 5, shift: 8,
+			new1: New256, new1a: New256a,
+		},
+		{
+			name: "512",
+			offset: []uint32{0x4AFE9FD9, 0xAC982AAC, 0x5F56E34B, 0x18203641,
+				0x42DBE7CE, 0x2EA79BC9, 0x34C192F6, 0xE948F68A,
+				0x00000D21, 0x00000000, 0xC9000000, 0xAC87D059,
+				0x309990AC, 0xDCA1E50F, 0x171F4416, 0xB86DB0B1},
+			primeLow: 0x157, digitOffset: 10, shift: 24,
+			new1: New512, new1a: New512a,
+		},
+		{
+			name: "1024",
+			offset: []uint32{0x71EE90B3, 0xAFF4B16C, 0xC6A93B21, 0x6BDE8CC9,
+				0xC005AE55, 0x555F256C, 0x2734510A, 0xEB6E7380,
+				0x0004C6D7, 0x00000000, 0x00000000, 0x00000000,
+				0x00000000, 0x00000000, 0x00000000, 0x00000000,
+				0x00000000, 0x00000000, 0x00000000, 0x00000000,
+				0x00000000, 0x9A21D900, 0xDA3674DA, 0x6C3BF34E,
+				0x23FDADA1, 0x4B29FC42, 0x591028B7, 0x32E56D5A,
+				0x758ECC4D, 0x005F7A76, 0x00000000, 0x00000000},
+			primeLow: 0x18D, digitOffset: 21, shift: 8,
+			new1: New1024, new1a: New1024a,
+		},
+	}
+
+	r := rand.New(rand.NewSource(1))
+	for _, c := range cases {
+		for n := 0; n < 20; n++ {
+			data := make([]byte, r.Intn(200))
+			r.Read(data)
+
+			wantA := wideDigitsBigEndian(wideDigits(c.offset, c.primeLow, c.digitOffset, c.shift, true, data))
+			if got := sumOf(c.new1a(), data); !bytes.Equal(got, wantA) {
+				t.Errorf("%s-bit FNV-1a: Sum(%x) = %x, want %x", c.name, data, got, wantA)
+			}
+
+			want1 := wideDigitsBigEndian(wideDigits(c.offset, c.primeLow, c.digitOffset, c.shift, false, data))
+			if got := sumOf(c.new1(), data); !bytes.Equal(got, want1) {
+				t.Errorf("%s-bit FNV-1: Sum(%x) = %x, want %x", c.name, data, got, want1)
+			}
+		}
+	}
+}
+
+func sumOf(h hash.Hash, data []byte) []byte {
+	h.Write(data)
+	return h.Sum(nil)
+}
@@ -0,0 +1,177 @@
+// Package siphash implements SipHash-2-4, a fast keyed pseudorandom function
+// designed by Jean-Philippe Aumasson and Daniel J. Bernstein. Unlike FNV-1a,
+// SipHash takes a secret key and is hard to predict or collide without it,
+// which makes it suitable for hash tables and other structures exposed to
+// untrusted input.
+//
+// "2-4" refers to the number of mixing rounds: two per absorbed 8-byte block
+// (c) and four during finalization (d).
+package siphash
+
+import (
+	"encoding/binary"
+	"hash"
+	"math/bits"
+)
+
+// Initialization constants, the ASCII strings "somepseudorandomlygeneratedbytes".
+const (
+	initV0 = 0x736f6d6570736575
+	initV1 = 0x646f72616e646f6d
+	initV2 = 0x6c7967656e657261
+	initV3 = 0x7465646279746573
+)
+
+const (
+	cRounds = 2 // mixing rounds per absorbed block
+	dRounds = 4 // mixing rounds during finalization
+)
+
+// sipRound is the SipHash mixing permutation (the "SipRound" of the spec),
+// applied to the running state v0..v3.
+func sipRound(v0, v1, v2, v3 *uint64) {
+	*v0 += *v1
+	*v1 = bits.RotateLeft64(*v1, 13)
+	*v1 ^= *v0
+	*v0 = bits.RotateLeft64(*v0, 32)
+
+	*v2 += *v3
+	*v3 = bits.RotateLeft64(*v3, 16)
+	*v3 ^= *v2
+
+	*v0 += *v3
+	*v3 = bits.RotateLeft64(*v3, 21)
+	*v3 ^= *v0
+
+	*v2 += *v1
+	*v1 = bits.RotateLeft64(*v1, 17)
+	*v1 ^= *v2
+	*v2 = bits.RotateLeft64(*v2, 32)
+}
+
+// absorbBlock folds the little-endian 64-bit block m into v0..v3: xor it into
+// v3, run cRounds of mixing, then xor it into v0.
+func absorbBlock(v0, v1, v2, v3 *uint64, m uint64) {
+	*v3 ^= m
+	for i := 0; i < cRounds; i++ {
+		sipRound(v0, v1, v2, v3)
+	}
+	*v0 ^= m
+}
+
+// digest128 is the streaming hash.Hash returned by New128. It buffers input
+// until a full 8-byte block is available, mirroring the way fnv's wide hashes
+// fold bytes into limb state incrementally.
+type digest128 struct {
+	v0, v1, v2, v3 uint64
+	k0, k1         uint64
+
+	buf    [8]byte
+	buflen int
+	length uint64
+}
+
+// New128 returns a new hash.Hash computing the 128-bit SipHash-2-4 of the
+// data written to it, keyed by key.
+func New128(key [16]byte) hash.Hash {
+	d := &digest128{
+		k0: binary.LittleEndian.Uint64(key[:8]),
+		k1: binary.LittleEndian.Uint64(key[8:]),
+	}
+	d.Reset()
+	return d
+}
+
+func (d *digest128) Reset() {
+	d.v0 = initV0 ^ d.k0
+	d.v1 = initV1 ^ d.k1
+	d.v2 = initV2 ^ d.k0
+	d.v3 = initV3 ^ d.k1
+	d.buflen = 0
+	d.length = 0
+}
+
+func (*digest128) Size() int { return 16 }
+
+func (*digest128) BlockSize() int { return 8 }
+
+func (d *digest128) Write(p []byte) (int, error) {
+	n := len(p)
+	d.length += uint64(n)
+
+	if d.buflen > 0 {
+		filled := copy(d.buf[d.buflen:], p)
+		d.buflen += filled
+		p = p[filled:]
+		if d.buflen < 8 {
+			return n, nil
+		}
+		absorbBlock(&d.v0, &d.v1, &d.v2, &d.v3, binary.LittleEndian.Uint64(d.buf[:]))
+		d.buflen = 0
+	}
+
+	for len(p) >= 8 {
+		absorbBlock(&d.v0, &d.v1, &d.v2, &d.v3, binary.LittleEndian.Uint64(p))
+		p = p[8:]
+	}
+
+	d.buflen = copy(d.buf[:], p)
+	return n, nil
+}
+
+// Sum appends the 128-bit digest, low half first, to in without mutating the
+// running state.
+func (d *digest128) Sum(in []byte) []byte {
+	v0, v1, v2, v3 := d.v0, d.v1, d.v2, d.v3
+
+	var last [8]byte
+	copy(last[:], d.buf[:d.buflen])
+	last[7] = byte(d.length)
+	absorbBlock(&v0, &v1, &v2, &v3, binary.LittleEndian.Uint64(last[:]))
+
+	v1 ^= 0xee
+	for i := 0; i < dRounds; i++ {
+		sipRound(&v0, &v1, &v2, &v3)
+	}
+	lo := v0 ^ v1 ^ v2 ^ v3
+
+	v1 ^= 0xdd
+	for i := 0; i < dRounds; i++ {
+		sipRound(&v0, &v1, &v2, &v3)
+	}
+	hi := v0 ^ v1 ^ v2 ^ v3
+
+	var out [16]byte
+	binary.LittleEndian.PutUint64(out[:8], lo)
+	binary.LittleEndian.PutUint64(out[8:], hi)
+	return append(in, out[:]...)
+}
+
+// Sum64 computes the 64-bit SipHash-2-4 of data keyed by key in one shot,
+// without the allocation a New128-based hash.Hash would require.
+func Sum64(key [16]byte, data []byte) uint64 {
+	k0 := binary.LittleEndian.Uint64(key[:8])
+	k1 := binary.LittleEndian.Uint64(key[8:])
+
+	v0 := initV0 ^ k0
+	v1 := initV1 ^ k1
+	v2 := initV2 ^ k0
+	v3 := initV3 ^ k1
+
+	n := len(data)
+	for len(data) >= 8 {
+		absorbBlock(&v0, &v1, &v2, &v3, binary.LittleEndian.Uint64(data))
+		data = data[8:]
+	}
+
+	var last [8]byte
+	copy(last[:], data)
+	last[7] = byte(n)
+	absorbBlock(&v0, &v1, &v2, &v3, binary.LittleEndian.Uint64(last[:]))
+
+	v2 ^= 0xff
+	for i := 0; i < dRounds; i++ {
+		sipRound(&v0, &v1, &v2, &v3)
+	}
+	return v0 ^ v1 ^ v2 ^ v3
+}
@@ -0,0 +1,171 @@
+package siphash
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math/bits"
+	"math/rand"
+	"testing"
+)
+
+// refRound and refSum are a from-scratch, non-incremental reimplementation of
+// SipHash-2-4 used to cross-check the streaming digest128/Sum64 code below on
+// randomized inputs, independent of the buffering logic under test.
+func refRound(v *[4]uint64) {
+	v[0] += v[1]
+	v[1] = bits.RotateLeft64(v[1], 13)
+	v[1] ^= v[0]
+	v[0] = bits.RotateLeft64(v[0], 32)
+
+	v[2] += v[3]
+	v[3] = bits.RotateLeft64(v[3], 16)
+	v[3] ^= v[2]
+
+	v[0] += v[3]
+	v[3] = bits.RotateLeft64(v[3], 21)
+	v[3] ^= v[0]
+
+	v[2] += v[1]
+	v[1] = bits.RotateLeft64(v[1], 17)
+	v[1] ^= v[2]
+	v[2] = bits.RotateLeft64(v[2], 32)
+}
+
+func refSum(key [16]byte, data []byte, want128 bool) []byte {
+	k0 := binary.LittleEndian.Uint64(key[:8])
+	k1 := binary.LittleEndian.Uint64(key[8:])
+	v := [4]uint64{
+		initV0 ^ k0,
+		initV1 ^ k1,
+		initV2 ^ k0,
+		initV3 ^ k1,
+	}
+
+	n := len(data)
+	for len(data) >= 8 {
+		m := binary.LittleEndian.Uint64(data[:8])
+		v[3] ^= m
+		refRound(&v)
+		refRound(&v)
+		v[0] ^= m
+		data = data[8:]
+	}
+
+	var last [8]byte
+	copy(last[:], data)
+	last[7] = byte(n)
+	m := binary.LittleEndian.Uint64(last[:])
+	v[3] ^= m
+	refRound(&v)
+	refRound(&v)
+	v[0] ^= m
+
+	if want128 {
+		v[1] ^= 0xee
+	} else {
+		v[2] ^= 0xff
+	}
+	for i := 0; i < 4; i++ {
+		refRound(&v)
+	}
+
+	var out [8]byte
+	binary.LittleEndian.PutUint64(out[:], v[0]^v[1]^v[2]^v[3])
+	result := append([]byte(nil), out[:]...)
+	if !want128 {
+		return result
+	}
+
+	v[1] ^= 0xdd
+	for i := 0; i < 4; i++ {
+		refRound(&v)
+	}
+	binary.LittleEndian.PutUint64(out[:], v[0]^v[1]^v[2]^v[3])
+	return append(result, out[:]...)
+}
+
+func randKey(r *rand.Rand) [16]byte {
+	var key [16]byte
+	r.Read(key[:])
+	return key
+}
+
+func TestSum64MatchesReference(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for n := 0; n < 200; n++ {
+		key := randKey(r)
+		data := make([]byte, r.Intn(200))
+		r.Read(data)
+
+		var want uint64
+		wb := refSum(key, data, false)
+		want = binary.LittleEndian.Uint64(wb)
+
+		if got := Sum64(key, data); got != want {
+			t.Fatalf("Sum64(%x, %x) = %x, want %x", key, data, got, want)
+		}
+	}
+}
+
+func TestNew128MatchesReference(t *testing.T) {
+	r := rand.New(rand.NewSource(2))
+	for n := 0; n < 200; n++ {
+		key := randKey(r)
+		data := make([]byte, r.Intn(200))
+		r.Read(data)
+
+		want := refSum(key, data, true)
+
+		h := New128(key)
+		h.Write(data)
+		if got := h.Sum(nil); !bytes.Equal(got, want) {
+			t.Fatalf("New128(%x).Sum(%x) = %x, want %x", key, data, got, want)
+		}
+	}
+}
+
+func TestNew128WriteIsIncremental(t *testing.T) {
+	key := [16]byte{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15}
+	data := []byte("hello world!goodbye! I am a gopher!")
+
+	whole := New128(key)
+	whole.Write(data)
+
+	for split := 0; split <= len(data); split++ {
+		piecewise := New128(key)
+		piecewise.Write(data[:split])
+		piecewise.Write(data[split:])
+		if got, want := piecewise.Sum(nil), whole.Sum(nil); !bytes.Equal(got, want) {
+			t.Errorf("split at %d: incremental Write mismatch: %x != %x", split, got, want)
+		}
+	}
+}
+
+func TestNew128SumDoesNotMutateState(t *testing.T) {
+	h := New128([16]byte{})
+	h.Write([]byte("some data"))
+	first := h.Sum(nil)
+	second := h.Sum(nil)
+	if !bytes.Equal(first, second) {
+		t.Errorf("Sum is not idempotent: %x != %x", first, second)
+	}
+}
+
+func TestNew128SizeAndBlockSize(t *testing.T) {
+	h := New128([16]byte{})
+	if got := h.Size(); got != 16 {
+		t.Errorf("Size() = %d, want 16", got)
+	}
+	if got := h.BlockSize(); got != 8 {
+		t.Errorf("BlockSize() = %d, want 8", got)
+	}
+}
+
+func TestSum64DifferentKeysDiffer(t *testing.T) {
+	data := []byte("same message, different key")
+	a := Sum64([16]byte{0}, data)
+	b := Sum64([16]byte{1}, data)
+	if a == b {
+		t.Errorf("Sum64 produced the same output for different keys: %x", a)
+	}
+}